@@ -0,0 +1,96 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/drand/drand/demo/node"
+)
+
+func TestIsByzantine(t *testing.T) {
+	e := &Orchestrator{byzantineSpecs: []ByzantineSpec{
+		{NodeIndex: 2, Mode: ByzantineShareWithholder},
+	}}
+	if !e.isByzantine(2, ByzantineShareWithholder) {
+		t.Error("isByzantine(2, ShareWithholder) = false, want true")
+	}
+	if e.isByzantine(2, ByzantineClockSkewSigner) {
+		t.Error("isByzantine(2, ClockSkewSigner) = true, want false: node 2 is only armed as ShareWithholder")
+	}
+	if e.isByzantine(3, ByzantineShareWithholder) {
+		t.Error("isByzantine(3, ShareWithholder) = true, want false: node 3 has no spec")
+	}
+}
+
+func TestByzantineIndexes(t *testing.T) {
+	e := &Orchestrator{byzantineSpecs: []ByzantineSpec{
+		{NodeIndex: 1, Mode: ByzantineShareWithholder},
+		{NodeIndex: 3, Mode: ByzantineShareWithholder},
+	}}
+	got := e.byzantineIndexes()
+	want := []int{1, 3}
+	if len(got) != len(want) {
+		t.Fatalf("byzantineIndexes() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("byzantineIndexes()[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAssertByzantineExcluded(t *testing.T) {
+	e := &Orchestrator{
+		byzantineSpecs: []ByzantineSpec{{NodeIndex: 2, Mode: ByzantineShareWithholder}},
+		reshareIndex:   []int{0, 1, 3},
+		recorder:       newMultiRecorder(),
+	}
+	e.AssertByzantineExcluded()
+}
+
+func TestAssertByzantineExcludedPanicsIfStillPresent(t *testing.T) {
+	e := &Orchestrator{
+		byzantineSpecs: []ByzantineSpec{{NodeIndex: 2, Mode: ByzantineShareWithholder}},
+		reshareIndex:   []int{0, 1, 2},
+		recorder:       newMultiRecorder(),
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected AssertByzantineExcluded to panic: byzantine node 2 is still in reshareIndex")
+		}
+	}()
+	e.AssertByzantineExcluded()
+}
+
+func TestSetByzantineNodesShareWithholderIsRecorded(t *testing.T) {
+	e := &Orchestrator{
+		nodes:    []node.Node{&fakeNode{idx: 2, addr: "127.0.0.1:8002"}},
+		recorder: newMultiRecorder(),
+	}
+	e.SetByzantineNodes([]ByzantineSpec{{NodeIndex: 2, Mode: ByzantineShareWithholder}})
+	if !e.isByzantine(2, ByzantineShareWithholder) {
+		t.Error("SetByzantineNodes did not record the ShareWithholder spec")
+	}
+}
+
+func TestSetByzantineNodesPanicsForUnimplementedModes(t *testing.T) {
+	e := &Orchestrator{
+		nodes:    []node.Node{&fakeNode{idx: 2, addr: "127.0.0.1:8002"}},
+		recorder: newMultiRecorder(),
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected SetByzantineNodes to panic: clock_skew_signer has no protocol-level hook in this tree")
+		}
+	}()
+	e.SetByzantineNodes([]ByzantineSpec{{NodeIndex: 2, Mode: ByzantineClockSkewSigner}})
+}
+
+func TestSetByzantineNodesPanicsForMissingNode(t *testing.T) {
+	e := &Orchestrator{recorder: newMultiRecorder()}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected SetByzantineNodes to panic: node 9 does not exist")
+		}
+	}()
+	e.SetByzantineNodes([]ByzantineSpec{{NodeIndex: 9, Mode: ByzantineShareWithholder}})
+}
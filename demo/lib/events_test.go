@@ -0,0 +1,81 @@
+package lib
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type recordingRecorder struct {
+	got []Event
+}
+
+func (r *recordingRecorder) Emit(evt Event) {
+	r.got = append(r.got, evt)
+}
+
+func TestMultiRecorderFansOutToEveryRecorder(t *testing.T) {
+	a, b := &recordingRecorder{}, &recordingRecorder{}
+	m := newMultiRecorder(a, b)
+	evt := Event{Message: "hello"}
+	m.Emit(evt)
+	if len(a.got) != 1 || a.got[0].Message != "hello" {
+		t.Errorf("recorder a got %+v, want one event with Message hello", a.got)
+	}
+	if len(b.got) != 1 || b.got[0].Message != "hello" {
+		t.Errorf("recorder b got %+v, want one event with Message hello", b.got)
+	}
+}
+
+func TestJSONLRecorderRoundTrip(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "events.jsonl")
+	rec, err := NewJSONLRecorder(p)
+	if err != nil {
+		t.Fatalf("NewJSONLRecorder: %v", err)
+	}
+
+	idx := 1
+	rec.Emit(Event{RunID: "run1", BeaconID: "default", Phase: PhaseDKG, NodeIndex: &idx, Message: "starting"})
+	rec.Emit(Event{RunID: "run1", BeaconID: "default", Phase: PhaseShutdown, Message: "done"})
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		t.Fatalf("opening event log: %v", err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var evt Event
+		if err := json.Unmarshal(scanner.Bytes(), &evt); err != nil {
+			t.Fatalf("unmarshaling event line: %v", err)
+		}
+		events = append(events, evt)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanning event log: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("event log has %d lines, want 2", len(events))
+	}
+	if events[0].Phase != PhaseDKG || events[0].NodeIndex == nil || *events[0].NodeIndex != 1 || events[0].Message != "starting" {
+		t.Errorf("events[0] = %+v, want phase dkg, node 1, message starting", events[0])
+	}
+	if events[1].Phase != PhaseShutdown || events[1].Message != "done" {
+		t.Errorf("events[1] = %+v, want phase shutdown, message done", events[1])
+	}
+}
+
+func TestNewRunIDIsNonEmpty(t *testing.T) {
+	id := newRunID()
+	if id == "" {
+		t.Error("newRunID() returned an empty string")
+	}
+}
@@ -64,9 +64,36 @@ type Orchestrator struct {
 	dbEngineType      chain.StorageType
 	pgDSN             func() string
 	memDBSize         int
+	platform          Platform
+	runID             string
+	recorder          EventRecorder
+	closeRecorder     func() error
+	byzantineSpecs    []ByzantineSpec
+	wal               *walJournal
+	scenarioMu        sync.Mutex
+	killedByScenario  map[int]bool
+	pendingCrashes    map[int]FailurePoint
 }
 
+// NewOrchestrator builds an Orchestrator that deploys and runs every node
+// locally, as plain OS processes or in-process goroutines.
 func NewOrchestrator(c cfg.Config) *Orchestrator {
+	return newOrchestrator(c, NewLocalPlatform())
+}
+
+// NewOrchestratorWithPlatform is like NewOrchestrator but deploys the initial
+// node set through p instead of always using LocalPlatform.
+//
+// cfg.Config itself can't carry a Platform field: demo/node already imports
+// demo/cfg, and Platform's Deploy method returns node.Node, so cfg importing
+// this package's Platform type would create an import cycle. This
+// constructor is the supported way to point an Orchestrator at a
+// non-localhost Platform from its very first node.
+func NewOrchestratorWithPlatform(c cfg.Config, p Platform) *Orchestrator {
+	return newOrchestrator(c, p)
+}
+
+func newOrchestrator(c cfg.Config, p Platform) *Orchestrator {
 	c.BasePath = path.Join(os.TempDir(), "drand-full")
 	// cleanup the basePath before doing anything
 	_ = os.RemoveAll(c.BasePath)
@@ -77,7 +104,7 @@ func NewOrchestrator(c cfg.Config) *Orchestrator {
 	c.BeaconID = common.GetCanonicalBeaconID(c.BeaconID)
 
 	checkErr(os.MkdirAll(c.CertFolder, 0o740))
-	nodes, paths := createNodes(c)
+	nodes, paths := p.Deploy(c)
 
 	periodD, err := time.ParseDuration(c.Period)
 	checkErr(err)
@@ -101,10 +128,44 @@ func NewOrchestrator(c cfg.Config) *Orchestrator {
 		dbEngineType:      c.DBEngineType,
 		pgDSN:             c.PgDSN,
 		memDBSize:         c.MemDBSize,
+		platform:          p,
+		runID:             newRunID(),
+		killedByScenario:  make(map[int]bool),
+		pendingCrashes:    make(map[int]FailurePoint),
 	}
+	recorder, closeRecorder, err := logEvents(e.basePath)
+	checkErr(err)
+	e.recorder = recorder
+	e.closeRecorder = closeRecorder
+
+	wal, err := newWALJournal(e.basePath)
+	checkErr(err)
+	e.wal = wal
+	checkErr(e.wal.append(WALEntry{Type: WALNodesCreated, Config: newWALConfig(c)}))
+
 	return e
 }
 
+// journal appends a WAL entry if the orchestrator has a journal attached,
+// logging (but not panicking on) write failures so a full disk doesn't take
+// down an otherwise-healthy simulation.
+func (e *Orchestrator) journal(entry WALEntry) {
+	if e.wal == nil {
+		return
+	}
+	if err := e.wal.append(entry); err != nil {
+		e.emit(PhaseSetup, nil, nil, 0, err, "Failed to append WAL entry %s", entry.Type)
+	}
+}
+
+// SetPlatform overrides the Platform used for every node operation from this
+// point forward (SetupNewNodes, Start/Stop, Shutdown). It does not redeploy
+// nodes the constructor already created; use NewOrchestratorWithPlatform to
+// pick a Platform for the very first node set.
+func (e *Orchestrator) SetPlatform(p Platform) {
+	e.platform = p
+}
+
 func (e *Orchestrator) StartCurrentNodes(toExclude ...int) {
 	filtered := filterNodes(e.nodes, toExclude...)
 	e.startNodes(filtered)
@@ -115,11 +176,11 @@ func (e *Orchestrator) StartNewNodes() {
 }
 
 func (e *Orchestrator) startNodes(nodes []node.Node) {
-	fmt.Printf("[+] Starting all nodes\n")
+	e.emit(PhaseNodeStart, nil, nil, 0, nil, "Starting all nodes")
 	for _, n := range nodes {
-		fmt.Printf("\t- Starting node %s\n", n.PrivateAddr())
-		n.Start(e.certFolder, e.dbEngineType, e.pgDSN, e.memDBSize)
+		e.emit(PhaseNodeStart, nodeIdx(n.Index()), nil, 0, nil, "Starting node %s", n.PrivateAddr())
 	}
+	checkErr(e.platform.Start(nodes, e.certFolder, e.dbEngineType, e.pgDSN, e.memDBSize))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -140,19 +201,19 @@ func (e *Orchestrator) startNodes(nodes []node.Node) {
 			}
 
 			if !foundAll {
-				fmt.Println("[-] can not ping them all. Sleeping 2s...")
+				e.emit(PhaseNodeStart, nil, nil, 0, fmt.Errorf("ping"), "can not ping them all. Sleeping 2s...")
 				break
 			}
 			return
 		case <-ctx.Done():
-			fmt.Println("[-] can not ping all nodes in 30 seconds. Shutting down.")
+			e.emit(PhaseNodeStart, nil, nil, 0, fmt.Errorf("ping timeout"), "can not ping all nodes in 30 seconds. Shutting down.")
 			panic("failed to ping nodes in 30 seconds")
 		}
 	}
 }
 
 func (e *Orchestrator) RunDKG(timeout time.Duration) {
-	fmt.Println("[+] Running DKG for all nodes")
+	e.emit(PhaseDKG, nil, nil, 0, nil, "Running DKG for all nodes")
 	time.Sleep(100 * time.Millisecond)
 	startTime := time.Now() // Start timing the DKG process
 
@@ -167,20 +228,30 @@ func (e *Orchestrator) RunDKG(timeout time.Duration) {
 			}
 			wg.Done()
 		}()
-		fmt.Printf("\t- Running DKG for leader node %s\n", leader.PrivateAddr())
+		if e.crashNodeAt(leader, FailureBeforeDKG) {
+			return
+		}
+		e.emit(PhaseDKG, nodeIdx(leader.Index()), nil, 0, nil, "Running DKG for leader node %s", leader.PrivateAddr())
 		leader.RunDKG(e.n, e.thr, timeout, true, "", beaconOffset)
+		e.crashNodeAt(leader, FailureAfterDKG)
 	}()
 	time.Sleep(200 * time.Millisecond)
 	for _, n := range e.nodes[1:] {
 		n := n
-		fmt.Printf("\t- Running DKG for node %s\n", n.PrivateAddr())
+		e.emit(PhaseDKG, nodeIdx(n.Index()), nil, 0, nil, "Running DKG for node %s", n.PrivateAddr())
 		go func(n node.Node) {
-			n.RunDKG(e.n, e.thr, timeout, false, leader.PrivateAddr(), beaconOffset)
-			fmt.Println("\t FINISHED DKG")
-			if err := recover(); err != nil {
-				panicCh <- err
+			defer func() {
+				if err := recover(); err != nil {
+					panicCh <- err
+				}
+				wg.Done()
+			}()
+			if e.crashNodeAt(n, FailureBeforeDKG) {
+				return
 			}
-			wg.Done()
+			n.RunDKG(e.n, e.thr, timeout, false, leader.PrivateAddr(), beaconOffset)
+			e.emit(PhaseDKG, nodeIdx(n.Index()), nil, 0, nil, "FINISHED DKG")
+			e.crashNodeAt(n, FailureAfterDKG)
 		}(n)
 	}
 	wg.Wait()
@@ -191,37 +262,32 @@ func (e *Orchestrator) RunDKG(timeout time.Duration) {
 	}
 
 	duration := time.Since(startTime) // Calculate the duration of the DKG process
-	fmt.Println(duration)
-	fmt.Println("[+] Nodes finished running DKG. Checking keys...")
+	e.emit(PhaseDKG, nil, nil, duration, nil, "DKG round took %s", duration)
+	e.emit(PhaseDKG, nil, nil, 0, nil, "Nodes finished running DKG. Checking keys...")
 	// we pass the current group path
 	startTime = time.Now()
 	g := e.checkDKGNodes(e.nodes, e.groupPath)
-	KeysDuration := time.Since(startTime)
+	keysDuration := time.Since(startTime)
 	// overwrite group to group path
 	e.group = g
 	e.genesis = g.GenesisTime
 	checkErr(key.Save(e.groupPath, e.group, false))
-	fmt.Println("\t- Overwrite group with distributed key to ", e.groupPath)
-	logToFile(len(e.nodes), duration, KeysDuration)
-}
-
-func logToFile(nodeCount int, duration time.Duration, KeysDuration time.Duration) {
-	file, err := os.OpenFile("./test.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Printf("Error opening file: %v\n", err)
-		return
-	}
-	defer file.Close()
-
-	_, err = fmt.Fprintf(file, "%d,%v,%v\n", nodeCount, duration, KeysDuration)
-	if err != nil {
-		fmt.Printf("Error writing to file: %v\n", err)
+	e.emit(PhaseDKG, nil, nil, keysDuration, nil, "Overwrite group with distributed key to %s", e.groupPath)
+	// legacy per-run timing line: node count, DKG duration, key check duration
+	if f, err := os.OpenFile("./test.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644); err != nil {
+		e.emit(PhaseDKG, nil, nil, 0, err, "Failed to open test.log")
+	} else {
+		if _, err := fmt.Fprintf(f, "%d,%v,%v\n", len(e.nodes), duration, keysDuration); err != nil {
+			e.emit(PhaseDKG, nil, nil, 0, err, "Failed to write test.log")
+		}
+		_ = f.Close()
 	}
+	e.journal(WALEntry{Type: WALDKGCompleted, GroupHash: g.PublicKey.Key().String(), Genesis: e.genesis})
 }
 
 func (e *Orchestrator) checkDKGNodes(nodes []node.Node, groupPath string) *key.Group {
 	for {
-		fmt.Println("[+] Checking if chain info is present on all nodes...")
+		e.emit(PhaseDKG, nil, nil, 0, nil, "Checking if chain info is present on all nodes...")
 		var allFound = true
 		for _, n := range nodes {
 			if !n.ChainInfo(groupPath) {
@@ -230,17 +296,17 @@ func (e *Orchestrator) checkDKGNodes(nodes []node.Node, groupPath string) *key.G
 			}
 		}
 		if !allFound {
-			fmt.Println("[+] Chain info not present on all nodes. Sleeping 3s...")
+			e.emit(PhaseDKG, nil, nil, 0, nil, "Chain info not present on all nodes. Sleeping 3s...")
 			time.Sleep(3 * time.Second)
 		} else {
-			fmt.Println("[+] Chain info are present on all nodes. DKG finished.")
+			e.emit(PhaseDKG, nil, nil, 0, nil, "Chain info are present on all nodes. DKG finished.")
 			break
 		}
 	}
 
 	var g *key.Group
 	var lastNode string
-	fmt.Println("[+] Checking all created group file with collective key")
+	e.emit(PhaseDKG, nil, nil, 0, nil, "Checking all created group file with collective key")
 	for _, n := range nodes {
 		group := n.GetGroup()
 		if g == nil {
@@ -257,16 +323,17 @@ func (e *Orchestrator) checkDKGNodes(nodes []node.Node, groupPath string) *key.G
 
 func (e *Orchestrator) WaitGenesis() {
 	to := time.Until(time.Unix(e.genesis, 0))
-	fmt.Printf("[+] Sleeping %d until genesis happens\n", int(to.Seconds()))
+	e.emit(PhaseGenesis, nil, nil, to, nil, "Sleeping %d until genesis happens", int(to.Seconds()))
 	time.Sleep(to)
 	relax := 3 * time.Second
-	fmt.Printf("[+] Sleeping %s after genesis - leaving some time for rounds \n", relax)
+	e.emit(PhaseGenesis, nil, nil, relax, nil, "Sleeping %s after genesis - leaving some time for rounds", relax)
 	time.Sleep(relax)
 }
 
 func (e *Orchestrator) WaitTransition() {
 	to := time.Until(time.Unix(e.transition, 0))
 	currentRound := chain.CurrentRound(e.transition, e.periodD, e.genesis)
+	e.journal(WALEntry{Type: WALTransitionScheduled, Transition: e.transition})
 
 	fmt.Printf("[+] Sleeping %s until transition happens (transition time: %d) currentRound: %d\n", to, e.transition, currentRound)
 	time.Sleep(to)
@@ -318,9 +385,10 @@ func filterNodes(list []node.Node, exclude ...int) []node.Node {
 }
 
 func (e *Orchestrator) checkBeaconNodes(nodes []node.Node, group string, tryCurl bool) {
+	startTime := time.Now()
 	nRound, _ := chain.NextRound(time.Now().Unix(), e.periodD, e.genesis)
 	currRound := nRound - 1
-	fmt.Printf("[+] Checking randomness beacon for round %d via CLI\n", currRound)
+	e.emit(PhaseBeaconWait, nil, roundPtr(uint64(currRound)), 0, nil, "Checking randomness beacon for round %d via CLI", currRound)
 	var pubRand *drand.PublicRandResponse
 	var lastIndex int
 	for _, n := range nodes {
@@ -330,13 +398,13 @@ func (e *Orchestrator) checkBeaconNodes(nodes []node.Node, group string, tryCurl
 			if pubRand == nil {
 				pubRand = randResp
 				lastIndex = n.Index()
-				fmt.Printf("\t - Example command is: \"%s\"\n", cmd)
+				e.emit(PhaseBeaconWait, nodeIdx(n.Index()), roundPtr(uint64(currRound)), time.Since(startTime), nil, "Example command is: %q", cmd)
 				break
 			}
 
 			// we first check both are at the same round
 			if randResp.GetRound() != pubRand.GetRound() {
-				fmt.Println("[-] Mismatch between last index", lastIndex, " vs current index ", n.Index(), " - trying again in some time...")
+				e.emit(PhaseBeaconWait, nodeIdx(n.Index()), roundPtr(uint64(currRound)), 0, fmt.Errorf("round mismatch"), "Mismatch between last index %d vs current index %d - trying again in some time...", lastIndex, n.Index())
 				time.Sleep(100 * time.Millisecond)
 				// we try again
 				continue
@@ -346,10 +414,11 @@ func (e *Orchestrator) checkBeaconNodes(nodes []node.Node, group string, tryCurl
 				panic("[-] Inconsistent beacon signature between nodes")
 			}
 			// everything is good
+			e.emit(PhaseBeaconWait, nodeIdx(n.Index()), roundPtr(uint64(currRound)), time.Since(startTime), nil, "Beacon converged on node %s", n.PrivateAddr())
 			break
 		}
 	}
-	fmt.Println("[+] Checking randomness via HTTP API using curl")
+	e.emit(PhaseBeaconWait, nil, roundPtr(uint64(currRound)), time.Since(startTime), nil, "Checking randomness via HTTP API using curl")
 	var printed bool
 	for _, n := range nodes {
 		args := []string{"-k", "-s"}
@@ -376,14 +445,14 @@ func (e *Orchestrator) checkBeaconNodes(nodes []node.Node, group string, tryCurl
 		for i := 0; i < maxCurlRetries; i++ {
 			cmd := exec.Command("curl", args...)
 			if !printed {
-				fmt.Printf("\t- Example command: \"%s\"\n", strings.Join(cmd.Args, " "))
+				e.emit(PhaseBeaconWait, nodeIdx(n.Index()), roundPtr(uint64(currRound)), 0, nil, "Example command: %q", strings.Join(cmd.Args, " "))
 				printed = true
 			}
 			if tryCurl {
 				// curl returns weird error code
 				out, _ := cmd.CombinedOutput()
 				if len(out) == 0 {
-					fmt.Println("received empty response from curl. Retrying ...")
+					e.emit(PhaseBeaconWait, nodeIdx(n.Index()), roundPtr(uint64(currRound)), afterPeriodWait, fmt.Errorf("empty curl response"), "Received empty response from curl. Retrying...")
 					time.Sleep(afterPeriodWait)
 					continue
 				}
@@ -394,21 +463,19 @@ func (e *Orchestrator) checkBeaconNodes(nodes []node.Node, group string, tryCurl
 				if r.GetRound() != pubRand.GetRound() {
 					panic("[-] Inconsistent round from curl vs CLI")
 				} else if !bytes.Equal(r.GetSignature(), pubRand.GetSignature()) {
-					fmt.Printf("curl output: %s\n", out)
-					fmt.Printf("curl output rand: %x\n", r.GetSignature())
-					fmt.Printf("cli output: %s\n", pubRand)
-					fmt.Printf("cli output rand: %x\n", pubRand.GetSignature())
+					e.emit(PhaseBeaconWait, nodeIdx(n.Index()), roundPtr(uint64(currRound)), 0, fmt.Errorf("signature mismatch"),
+						"curl output: %s (rand %x) vs cli output: %s (rand %x)", out, r.GetSignature(), pubRand, pubRand.GetSignature())
 					panic("[-] Inconsistent signature from curl vs CLI")
 				}
 			} else {
-				fmt.Printf("\t[-] Issue with curl command at the moment\n")
+				e.emit(PhaseBeaconWait, nodeIdx(n.Index()), roundPtr(uint64(currRound)), 0, fmt.Errorf("curl disabled"), "Issue with curl command at the moment")
 			}
 			break
 		}
 	}
 	out, err := json.MarshalIndent(pubRand, "", "    ")
 	checkErr(err)
-	fmt.Printf("%s\n", out)
+	e.emit(PhaseBeaconWait, nil, roundPtr(uint64(currRound)), time.Since(startTime), nil, "%s", out)
 }
 
 func (e *Orchestrator) SetupNewNodes(n int) {
@@ -429,7 +496,8 @@ func (e *Orchestrator) SetupNewNodes(n int) {
 		MemDBSize:    e.memDBSize,
 	}
 	//  offset int, period, basePath, certFolder string, tls bool, binary string, sch scheme.Scheme, beaconID string, isCandidate bool
-	e.newNodes, e.newPaths = createNodes(c)
+	e.newNodes, e.newPaths = e.platform.Deploy(c)
+	e.journal(WALEntry{Type: WALNewNodesSetup, Config: newWALConfig(c)})
 }
 
 // UpdateBinary will set the 'binary' to use for the node at 'idx'
@@ -447,22 +515,23 @@ func (e *Orchestrator) UpdateGlobalBinary(binary string, isCandidate bool) {
 }
 
 func (e *Orchestrator) CreateResharingGroup(oldToRemove, threshold int) {
-	fmt.Println("[+] Setting up the nodes for the resharing")
+	e.emit(PhaseReshare, nil, nil, 0, nil, "Setting up the nodes for the resharing")
 	// create paths that contains old node + new nodes
 	for _, n := range e.nodes[oldToRemove:] {
-		fmt.Printf("\t- Adding current node %s\n", n.PrivateAddr())
+		e.emit(PhaseReshare, nodeIdx(n.Index()), nil, 0, nil, "Adding current node %s", n.PrivateAddr())
 		e.reshareIndex = append(e.reshareIndex, n.Index())
 		e.reshareNodes = append(e.reshareNodes, n)
 	}
 	for _, n := range e.newNodes {
-		fmt.Printf("\t- Adding new node %s\n", n.PrivateAddr())
+		e.emit(PhaseReshare, nodeIdx(n.Index()), nil, 0, nil, "Adding new node %s", n.PrivateAddr())
 		e.reshareIndex = append(e.reshareIndex, n.Index())
 		e.reshareNodes = append(e.reshareNodes, n)
 	}
 	e.resharePaths = append(e.resharePaths, e.paths[oldToRemove:]...)
 	e.resharePaths = append(e.resharePaths, e.newPaths...)
 	e.newThr = threshold
-	fmt.Printf("[+] Stopping old nodes\n")
+	e.journal(WALEntry{Type: WALReshareGroupCreated, ReshareIndex: e.reshareIndex, NewThr: e.newThr})
+	e.emit(PhaseReshare, nil, nil, 0, nil, "Stopping old nodes")
 	for _, n := range e.nodes {
 		var found bool
 		for _, idx := range e.reshareIndex {
@@ -472,8 +541,9 @@ func (e *Orchestrator) CreateResharingGroup(oldToRemove, threshold int) {
 			}
 		}
 		if !found {
-			fmt.Printf("\t- Stopping old node %s\n", n.PrivateAddr())
-			n.Stop()
+			e.emit(PhaseNodeStop, nodeIdx(n.Index()), nil, 0, nil, "Stopping old node %s, excluded from the resharing group", n.PrivateAddr())
+			e.journal(WALEntry{Type: WALNodeStopped, NodeIndex: n.Index(), CtrlAddr: n.CtrlAddr()})
+			checkErr(e.platform.Stop([]node.Node{n}))
 		}
 	}
 }
@@ -488,11 +558,16 @@ func (e *Orchestrator) isNew(n node.Node) bool {
 }
 
 func (e *Orchestrator) RunResharing(timeout string) {
-	fmt.Println("[+] Running DKG for resharing nodes")
+	startTime := time.Now()
+	e.emit(PhaseReshare, nil, nil, 0, nil, "Running DKG for resharing nodes")
+	e.journal(WALEntry{Type: WALReshareStarted})
 	nodes := len(e.reshareNodes)
 	thr := e.newThr
 	groupCh := make(chan *key.Group, 1)
 	leader := e.reshareNodes[0]
+	if e.isByzantine(leader.Index(), ByzantineShareWithholder) {
+		panic(fmt.Errorf("[-] node %s is the resharing leader and cannot also be a byzantine share withholder", leader.PrivateAddr()))
+	}
 	panicCh := make(chan interface{}, 1)
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -502,13 +577,19 @@ func (e *Orchestrator) RunResharing(timeout string) {
 				panicCh <- err
 			}
 		}()
+		if e.crashNodeAt(leader, FailureBeforeReshare) {
+			wg.Done()
+			groupCh <- nil
+			return
+		}
 		p := ""
 		if e.isNew(leader) {
 			p = e.groupPath
 		}
-		fmt.Printf("\t- Running DKG for leader node %s\n", leader.PrivateAddr())
+		e.emit(PhaseReshare, nodeIdx(leader.Index()), nil, 0, nil, "Running DKG for leader node %s", leader.PrivateAddr())
 		group := leader.RunReshare(nodes, thr, p, timeout, true, "", beaconOffset)
-		fmt.Printf("\t- Resharing DONE for leader node %s\n", leader.PrivateAddr())
+		e.emit(PhaseReshare, nodeIdx(leader.Index()), nil, 0, nil, "Resharing DONE for leader node %s", leader.PrivateAddr())
+		e.crashNodeAt(leader, FailureAfterReshare)
 		wg.Done()
 		groupCh <- group
 	}()
@@ -520,7 +601,11 @@ func (e *Orchestrator) RunResharing(timeout string) {
 		if e.isNew(n) {
 			p = e.groupPath
 		}
-		fmt.Printf("\t- Running DKG for node %s\n", n.PrivateAddr())
+		if e.isByzantine(n.Index(), ByzantineShareWithholder) {
+			e.emit(PhaseReshare, nodeIdx(n.Index()), nil, 0, nil, "Node %s withholding its share: skipping its RunReshare call", n.PrivateAddr())
+			continue
+		}
+		e.emit(PhaseReshare, nodeIdx(n.Index()), nil, 0, nil, "Running DKG for node %s", n.PrivateAddr())
 		wg.Add(1)
 		go func(n node.Node) {
 			defer func() {
@@ -529,8 +614,13 @@ func (e *Orchestrator) RunResharing(timeout string) {
 					panicCh <- err
 				}
 			}()
+			if e.crashNodeAt(n, FailureBeforeReshare) {
+				wg.Done()
+				return
+			}
 			n.RunReshare(nodes, thr, p, timeout, false, leader.PrivateAddr(), beaconOffset)
-			fmt.Printf("\t- Resharing DONE for node %s\n", n.PrivateAddr())
+			e.emit(PhaseReshare, nodeIdx(n.Index()), nil, 0, nil, "Resharing DONE for node %s", n.PrivateAddr())
+			e.crashNodeAt(n, FailureAfterReshare)
 			wg.Done()
 		}(n)
 	}
@@ -541,23 +631,31 @@ func (e *Orchestrator) RunResharing(timeout string) {
 		panic(p)
 	default:
 	}
-	// we pass the new group file
-	g := e.checkDKGNodes(e.reshareNodes, e.newGroupPath)
+	// withholders never ran RunReshare, so they won't have the new group
+	// file; exclude them from the post-reshare consistency check.
+	checkNodes := filterNodes(e.reshareNodes, e.byzantineIndexes()...)
+	g := e.checkDKGNodes(checkNodes, e.newGroupPath)
 	e.newGroup = g
 	e.transition = g.TransitionTime
 	checkErr(key.Save(e.newGroupPath, e.newGroup, false))
-	fmt.Println("\t- Overwrite reshared group with distributed key to ", e.newGroupPath)
-	fmt.Println("[+] Check previous distributed key is the same as the new one")
+	duration := time.Since(startTime)
+	e.emit(PhaseReshare, nil, nil, duration, nil, "Overwrite reshared group with distributed key to %s", e.newGroupPath)
+	e.journal(WALEntry{Type: WALReshareCompleted, GroupHash: g.PublicKey.Key().String(), Transition: e.transition})
+	e.emit(PhaseReshare, nil, nil, 0, nil, "Check previous distributed key is the same as the new one")
 	oldgroup := new(key.Group)
 	newgroup := new(key.Group)
 	checkErr(key.Load(e.groupPath, oldgroup))
 	checkErr(key.Load(e.newGroupPath, newgroup))
 	if !oldgroup.PublicKey.Key().Equal(newgroup.PublicKey.Key()) {
-		fmt.Printf("[-] Invalid distributed key !\n")
+		e.emit(PhaseReshare, nil, nil, 0, fmt.Errorf("key mismatch"), "Invalid distributed key!")
 	}
 }
 
-func createNodes(cfg cfg.Config) ([]node.Node, []string) {
+// newNodeHandles builds one node.Node handle per node described by cfg,
+// without touching disk; createNodes additionally writes their certificates
+// and public keys, while reattachNodes reuses the handles as-is to talk to
+// an already-running set of nodes.
+func newNodeHandles(cfg cfg.Config) []node.Node {
 	var nodes []node.Node
 	for i := 0; i < cfg.N; i++ {
 		idx := i + cfg.Offset
@@ -567,8 +665,15 @@ func createNodes(cfg cfg.Config) ([]node.Node, []string) {
 		} else {
 			n = node.NewLocalNode(idx, "127.0.0.1", cfg)
 		}
-		n.WriteCertificate(path.Join(cfg.CertFolder, fmt.Sprintf("cert-%d", idx)))
 		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+func createNodes(cfg cfg.Config) ([]node.Node, []string) {
+	nodes := newNodeHandles(cfg)
+	for _, n := range nodes {
+		n.WriteCertificate(path.Join(cfg.CertFolder, fmt.Sprintf("cert-%d", n.Index())))
 		fmt.Printf("\t- Created node %s at %s --> ctrl port: %s\n", n.PrivateAddr(), cfg.BasePath, n.CtrlAddr())
 	}
 	// write public keys from all nodes
@@ -585,8 +690,9 @@ func (e *Orchestrator) StopNodes(idxs ...int) {
 	for _, n := range e.nodes {
 		for _, idx := range idxs {
 			if n.Index() == idx {
-				fmt.Printf("[+] Stopping node %s to simulate a node failure\n", n.PrivateAddr())
-				n.Stop()
+				e.emit(PhaseNodeStop, nodeIdx(n.Index()), nil, 0, nil, "Stopping node %s to simulate a node failure", n.PrivateAddr())
+				e.journal(WALEntry{Type: WALNodeStopped, NodeIndex: n.Index(), CtrlAddr: n.CtrlAddr()})
+				checkErr(e.platform.Stop([]node.Node{n}))
 			}
 		}
 	}
@@ -594,7 +700,7 @@ func (e *Orchestrator) StopNodes(idxs ...int) {
 
 func (e *Orchestrator) StopAllNodes(toExclude ...int) {
 	filtered := filterNodes(e.nodes, toExclude...)
-	fmt.Printf("[+] Stopping the rest (%d nodes) for a complete failure\n", len(filtered))
+	e.emit(PhaseNodeStop, nil, nil, 0, nil, "Stopping the rest (%d nodes) for a complete failure", len(filtered))
 	for _, n := range filtered {
 		e.StopNodes(n.Index())
 	}
@@ -612,16 +718,18 @@ func (e *Orchestrator) StartNode(idxs ...int) {
 			panic("node to start doesn't exist")
 		}
 
-		fmt.Printf("[+] Attempting to start node %s again ...\n", foundNode.PrivateAddr())
-		// Here we send the nil values to the start method to allow the node to reconnect to the same database
-		err := foundNode.Start(e.certFolder, "", nil, e.memDBSize)
+		startTime := time.Now()
+		e.emit(PhaseNodeStart, nodeIdx(foundNode.Index()), nil, 0, nil, "Attempting to start node %s again ...", foundNode.PrivateAddr())
+		// Here we send the zero values to the start method to allow the node to reconnect to the same database
+		err := e.platform.Start([]node.Node{foundNode}, e.certFolder, "", nil, e.memDBSize)
 		if err != nil {
 			panic(fmt.Errorf("[-] Could not start node %s error: %v", foundNode.PrivateAddr(), err))
 		}
 		var started bool
 		for trial := 1; trial < 10; trial += 1 {
 			if foundNode.Ping() {
-				fmt.Printf("\t- Node %s started correctly\n", foundNode.PrivateAddr())
+				e.emit(PhaseNodeStart, nodeIdx(foundNode.Index()), nil, time.Since(startTime), nil, "Node %s started correctly", foundNode.PrivateAddr())
+				e.journal(WALEntry{Type: WALNodeStarted, NodeIndex: foundNode.Index(), CtrlAddr: foundNode.CtrlAddr()})
 				started = true
 				break
 			}
@@ -644,17 +752,25 @@ func (e *Orchestrator) PrintLogs() {
 }
 
 func (e *Orchestrator) Shutdown() {
-	fmt.Println("[+] Shutdown all nodes")
+	e.emit(PhaseShutdown, nil, nil, 0, nil, "Shutdown all nodes")
 	for _, no := range e.nodes {
-		fmt.Printf("\t- Stopping old node %s\n", no.PrivateAddr())
-		go no.Stop()
+		no := no
+		e.emit(PhaseShutdown, nodeIdx(no.Index()), nil, 0, nil, "Stopping old node %s", no.PrivateAddr())
+		go func() { _ = e.platform.Stop([]node.Node{no}) }()
 	}
 	for _, no := range e.newNodes {
-		fmt.Printf("\t- Stopping new node %s\n", no.PrivateAddr())
-		go no.Stop()
-		fmt.Println("\t- Successfully stopped Node", no.Index(), "(", no.PrivateAddr(), ")")
+		no := no
+		e.emit(PhaseShutdown, nodeIdx(no.Index()), nil, 0, nil, "Stopping new node %s", no.PrivateAddr())
+		go func() { _ = e.platform.Stop([]node.Node{no}) }()
+		e.emit(PhaseShutdown, nodeIdx(no.Index()), nil, 0, nil, "Successfully stopped Node %d (%s)", no.Index(), no.PrivateAddr())
+	}
+	e.emit(PhaseShutdown, nil, nil, 0, nil, "Successfully sent Stop command to all node")
+	if e.closeRecorder != nil {
+		_ = e.closeRecorder()
+	}
+	if e.wal != nil {
+		_ = e.wal.close()
 	}
-	fmt.Println("\t- Successfully sent Stop command to all node")
 	time.Sleep(3 * time.Minute)
 }
 
@@ -0,0 +1,76 @@
+package lib
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/demo/node"
+)
+
+// fakePlatformNode embeds the node.Node interface (always nil) so tests can
+// satisfy it while only overriding the methods LocalPlatform calls.
+type fakePlatformNode struct {
+	node.Node
+	idx         int
+	startCalled bool
+	startErr    error
+	stopCalled  bool
+}
+
+func (f *fakePlatformNode) Index() int { return f.idx }
+
+func (f *fakePlatformNode) PrivateAddr() string { return fmt.Sprintf("fake-node-%d", f.idx) }
+
+func (f *fakePlatformNode) Start(certFolder string, dbEngineType chain.StorageType, pgDSN func() string, memDBSize int) error {
+	f.startCalled = true
+	return f.startErr
+}
+
+func (f *fakePlatformNode) Stop() {
+	f.stopCalled = true
+}
+
+func TestLocalPlatformStart(t *testing.T) {
+	p := NewLocalPlatform()
+	n := &fakePlatformNode{idx: 1}
+	if err := p.Start([]node.Node{n}, "/certs", chain.StorageType(""), nil, 0); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if !n.startCalled {
+		t.Error("Start did not call through to the node")
+	}
+}
+
+func TestLocalPlatformStartPropagatesError(t *testing.T) {
+	p := NewLocalPlatform()
+	n := &fakePlatformNode{idx: 1, startErr: fmt.Errorf("start failed")}
+	if err := p.Start([]node.Node{n}, "/certs", chain.StorageType(""), nil, 0); err == nil {
+		t.Fatal("expected Start to propagate the node's error, got nil")
+	}
+}
+
+func TestLocalPlatformStop(t *testing.T) {
+	p := NewLocalPlatform()
+	n := &fakePlatformNode{idx: 1}
+	if err := p.Stop([]node.Node{n}); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if !n.stopCalled {
+		t.Error("Stop did not call through to the node")
+	}
+}
+
+func TestLocalPlatformExecIsUnsupported(t *testing.T) {
+	p := NewLocalPlatform()
+	if _, err := p.Exec(&fakePlatformNode{idx: 1}, "status"); err == nil {
+		t.Fatal("expected Exec to return an error, got nil")
+	}
+}
+
+func TestLocalPlatformCollectArtifactsIsNoop(t *testing.T) {
+	p := NewLocalPlatform()
+	if err := p.CollectArtifacts([]node.Node{&fakePlatformNode{idx: 1}}, t.TempDir()); err != nil {
+		t.Fatalf("CollectArtifacts: %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package lib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/demo/node"
+)
+
+// fakeNode embeds the node.Node interface (always nil) so tests can satisfy
+// it while only overriding the couple of methods addrsOf calls.
+type fakeNode struct {
+	node.Node
+	idx  int
+	addr string
+}
+
+func (f *fakeNode) Index() int          { return f.idx }
+func (f *fakeNode) PrivateAddr() string { return f.addr }
+
+func writeScenarioFile(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o640); err != nil {
+		t.Fatalf("writing scenario file: %v", err)
+	}
+	return p
+}
+
+func TestLoadScenarioYAML(t *testing.T) {
+	p := writeScenarioFile(t, "scenario.yaml", `
+name: kill-and-recover
+require_convergence: true
+events:
+  - round: 3
+    type: kill_node
+    nodes: [2]
+  - round: 6
+    type: start_node
+    nodes: [2]
+`)
+	s, err := LoadScenario(p)
+	if err != nil {
+		t.Fatalf("LoadScenario: %v", err)
+	}
+	if s.Name != "kill-and-recover" {
+		t.Errorf("Name = %q, want %q", s.Name, "kill-and-recover")
+	}
+	if !s.RequireConvergence {
+		t.Errorf("RequireConvergence = false, want true")
+	}
+	if len(s.Events) != 2 {
+		t.Fatalf("len(Events) = %d, want 2", len(s.Events))
+	}
+	if s.Events[0].Type != EventKillNode || s.Events[0].Round != 3 {
+		t.Errorf("Events[0] = %+v, want round 3 kill_node", s.Events[0])
+	}
+	if s.Events[1].Type != EventStartNode || s.Events[1].Round != 6 {
+		t.Errorf("Events[1] = %+v, want round 6 start_node", s.Events[1])
+	}
+}
+
+func TestLoadScenarioJSON(t *testing.T) {
+	p := writeScenarioFile(t, "scenario.json", `{
+		"name": "skew-test",
+		"events": [
+			{"round": 1, "type": "clock_skew", "nodes": [0], "skew": 5000000000}
+		]
+	}`)
+	s, err := LoadScenario(p)
+	if err != nil {
+		t.Fatalf("LoadScenario: %v", err)
+	}
+	if s.Name != "skew-test" {
+		t.Errorf("Name = %q, want %q", s.Name, "skew-test")
+	}
+	if len(s.Events) != 1 || s.Events[0].Skew != 5*time.Second {
+		t.Errorf("Events = %+v, want one clock_skew event with a 5s skew", s.Events)
+	}
+}
+
+func TestLoadScenarioUnsupportedExtension(t *testing.T) {
+	p := writeScenarioFile(t, "scenario.txt", "name: whatever")
+	if _, err := LoadScenario(p); err == nil {
+		t.Fatal("expected an error for an unsupported extension, got nil")
+	}
+}
+
+func TestContainsIdx(t *testing.T) {
+	idxs := []int{1, 3, 5}
+	for _, tc := range []struct {
+		idx  int
+		want bool
+	}{
+		{1, true},
+		{5, true},
+		{2, false},
+		{0, false},
+	} {
+		if got := containsIdx(idxs, tc.idx); got != tc.want {
+			t.Errorf("containsIdx(%v, %d) = %v, want %v", idxs, tc.idx, got, tc.want)
+		}
+	}
+}
+
+func TestAddrsOf(t *testing.T) {
+	nodes := []node.Node{
+		&fakeNode{idx: 0, addr: "127.0.0.1:8000"},
+		&fakeNode{idx: 1, addr: "127.0.0.1:8001"},
+		&fakeNode{idx: 2, addr: "127.0.0.1:8002"},
+	}
+	got := addrsOf(nodes, []int{0, 2})
+	want := []string{"127.0.0.1:8000", "127.0.0.1:8002"}
+	if len(got) != len(want) {
+		t.Fatalf("addrsOf = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("addrsOf[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
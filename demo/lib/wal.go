@@ -0,0 +1,290 @@
+package lib
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/crypto"
+	"github.com/drand/drand/demo/cfg"
+	"github.com/drand/drand/demo/node"
+)
+
+// WALEntryType identifies one kind of state transition recorded in the
+// orchestrator's write-ahead journal.
+type WALEntryType string
+
+const (
+	WALNodesCreated        WALEntryType = "nodes_created"
+	WALDKGCompleted        WALEntryType = "dkg_completed"
+	WALNewNodesSetup       WALEntryType = "new_nodes_setup"
+	WALReshareGroupCreated WALEntryType = "reshare_group_created"
+	WALReshareStarted      WALEntryType = "reshare_started"
+	WALReshareCompleted    WALEntryType = "reshare_completed"
+	WALNodeStopped         WALEntryType = "node_stopped"
+	WALNodeStarted         WALEntryType = "node_started"
+	WALTransitionScheduled WALEntryType = "transition_scheduled"
+)
+
+// WALEntry is one line of the orchestrator's journal: enough to reconstruct
+// in-memory Orchestrator state and reattach to still-running nodes after a
+// driver restart.
+type WALEntry struct {
+	Type WALEntryType `json:"type"`
+	// NodeIndex/CtrlAddr identify a single node for WALNodeStopped/WALNodeStarted.
+	NodeIndex int    `json:"node_index,omitempty"`
+	CtrlAddr  string `json:"ctrl_addr,omitempty"`
+	GroupHash string `json:"group_hash,omitempty"`
+	Genesis   int64  `json:"genesis,omitempty"`
+
+	Transition int64 `json:"transition,omitempty"`
+
+	// Config is a journaled snapshot of the cfg.Config nodes were created
+	// from: the initial set for WALNodesCreated, the additional resharing
+	// nodes for WALNewNodesSetup. It's a walConfig rather than a *cfg.Config
+	// because cfg.Config.PgDSN is a func() string, and encoding/json refuses
+	// to marshal any struct containing a func field, nil or not.
+	Config *walConfig `json:"config,omitempty"`
+
+	// ReshareIndex and NewThr describe the resharing group for
+	// WALReshareGroupCreated: which node indices (old survivors + new
+	// nodes) take part, and the new threshold.
+	ReshareIndex []int `json:"reshare_index,omitempty"`
+	NewThr       int   `json:"new_thr,omitempty"`
+}
+
+// walConfig is the serializable subset of cfg.Config: every field except
+// PgDSN, which holds a func() string that encoding/json can never marshal.
+// A node resumed from the WAL gets PgDSN back as nil, the same as any other
+// reattached node reconnecting to its already-running database without
+// re-establishing a DSN (see reattachNodes/newNodeHandles).
+type walConfig struct {
+	N            int               `json:"n"`
+	Thr          int               `json:"thr"`
+	Offset       int               `json:"offset"`
+	Period       string            `json:"period"`
+	BasePath     string            `json:"base_path"`
+	CertFolder   string            `json:"cert_folder"`
+	WithTLS      bool              `json:"with_tls"`
+	WithCurl     bool              `json:"with_curl"`
+	Binary       string            `json:"binary"`
+	Scheme       *crypto.Scheme    `json:"scheme,omitempty"`
+	BeaconID     string            `json:"beacon_id"`
+	IsCandidate  bool              `json:"is_candidate"`
+	DBEngineType chain.StorageType `json:"db_engine_type"`
+	MemDBSize    int               `json:"mem_db_size"`
+}
+
+// newWALConfig snapshots the serializable fields of c for journaling.
+func newWALConfig(c cfg.Config) *walConfig {
+	return &walConfig{
+		N:            c.N,
+		Thr:          c.Thr,
+		Offset:       c.Offset,
+		Period:       c.Period,
+		BasePath:     c.BasePath,
+		CertFolder:   c.CertFolder,
+		WithTLS:      c.WithTLS,
+		WithCurl:     c.WithCurl,
+		Binary:       c.Binary,
+		Scheme:       c.Scheme,
+		BeaconID:     c.BeaconID,
+		IsCandidate:  c.IsCandidate,
+		DBEngineType: c.DBEngineType,
+		MemDBSize:    c.MemDBSize,
+	}
+}
+
+// toConfig rebuilds a cfg.Config from a journaled snapshot. PgDSN is always
+// nil: the resumed node reconnects to its existing database rather than
+// re-establishing a DSN, the same as any other reattached node.
+func (w *walConfig) toConfig() cfg.Config {
+	return cfg.Config{
+		N:            w.N,
+		Thr:          w.Thr,
+		Offset:       w.Offset,
+		Period:       w.Period,
+		BasePath:     w.BasePath,
+		CertFolder:   w.CertFolder,
+		WithTLS:      w.WithTLS,
+		WithCurl:     w.WithCurl,
+		Binary:       w.Binary,
+		Scheme:       w.Scheme,
+		BeaconID:     w.BeaconID,
+		IsCandidate:  w.IsCandidate,
+		DBEngineType: w.DBEngineType,
+		MemDBSize:    w.MemDBSize,
+	}
+}
+
+// walJournal appends WALEntry records to basePath/orchestrator.wal, fsyncing
+// after every write so a crash of the driving process never loses a
+// transition that already happened on the nodes themselves.
+type walJournal struct {
+	file *os.File
+}
+
+func newWALJournal(basePath string) (*walJournal, error) {
+	f, err := os.OpenFile(path.Join(basePath, "orchestrator.wal"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("opening orchestrator WAL: %w", err)
+	}
+	return &walJournal{file: f}, nil
+}
+
+func (w *walJournal) append(entry WALEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling WAL entry: %w", err)
+	}
+	if _, err := w.file.Write(append(raw, '\n')); err != nil {
+		return fmt.Errorf("writing WAL entry: %w", err)
+	}
+	return w.file.Sync()
+}
+
+func (w *walJournal) close() error {
+	return w.file.Close()
+}
+
+// readWAL replays every entry recorded at basePath/orchestrator.wal, in
+// order. A malformed trailing line (e.g. a write torn by a kill -9 mid-sync)
+// is skipped rather than discarding the whole, otherwise-valid journal.
+func readWAL(basePath string) ([]WALEntry, error) {
+	f, err := os.Open(path.Join(basePath, "orchestrator.wal"))
+	if err != nil {
+		return nil, fmt.Errorf("opening orchestrator WAL: %w", err)
+	}
+	defer f.Close()
+
+	var entries []WALEntry
+	scanner := bufio.NewScanner(f)
+	// journal lines can carry a full config snapshot; grow the buffer accordingly.
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry WALEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			fmt.Printf("[-] skipping truncated WAL line: %v\n", err)
+			break
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanning orchestrator WAL: %w", err)
+	}
+	return entries, nil
+}
+
+// NewOrchestratorFromState rebuilds an Orchestrator from the WAL journal
+// under basePath, reattaching to the node handles described in its last
+// WALNodesCreated entry and resuming whatever state the journal's last
+// entries describe (completed DKG group, in-progress reshare, transition
+// time still pending). It does not fork new node processes: it reconstructs
+// the same node.Node handles createNodes would have produced, which talk to
+// the already-running processes via their deterministic control addresses.
+func NewOrchestratorFromState(basePath string) (*Orchestrator, error) {
+	entries, err := readWAL(basePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("orchestrator WAL at %s is empty, nothing to resume", basePath)
+	}
+
+	e := &Orchestrator{
+		basePath:         basePath,
+		groupPath:        path.Join(basePath, "group.toml"),
+		newGroupPath:     path.Join(basePath, "group2.toml"),
+		platform:         NewLocalPlatform(),
+		runID:            newRunID(),
+		killedByScenario: make(map[int]bool),
+		pendingCrashes:   make(map[int]FailurePoint),
+	}
+	recorder, closeRecorder, err := logEvents(basePath)
+	if err != nil {
+		return nil, err
+	}
+	e.recorder = recorder
+	e.closeRecorder = closeRecorder
+
+	wal, err := newWALJournal(basePath)
+	if err != nil {
+		return nil, err
+	}
+	e.wal = wal
+
+	for _, entry := range entries {
+		switch entry.Type {
+		case WALNodesCreated:
+			if entry.Config == nil {
+				return nil, fmt.Errorf("nodes_created WAL entry missing config")
+			}
+			c := entry.Config.toConfig()
+			e.n = c.N
+			e.thr = c.Thr
+			e.scheme = c.Scheme
+			e.period = c.Period
+			e.certFolder = c.CertFolder
+			e.tls = c.WithTLS
+			e.withCurl = c.WithCurl
+			e.binary = c.Binary
+			e.isBinaryCandidate = c.IsCandidate
+			e.beaconID = c.BeaconID
+			e.dbEngineType = c.DBEngineType
+			e.pgDSN = c.PgDSN
+			e.memDBSize = c.MemDBSize
+			periodD, err := time.ParseDuration(c.Period)
+			if err != nil {
+				return nil, fmt.Errorf("parsing period from WAL config: %w", err)
+			}
+			e.periodD = periodD
+			e.nodes, e.paths = reattachNodes(c)
+		case WALDKGCompleted:
+			e.genesis = entry.Genesis
+		case WALNewNodesSetup:
+			if entry.Config == nil {
+				return nil, fmt.Errorf("new_nodes_setup WAL entry missing config")
+			}
+			e.newNodes, e.newPaths = reattachNodes(entry.Config.toConfig())
+		case WALReshareGroupCreated:
+			e.reshareIndex = entry.ReshareIndex
+			e.newThr = entry.NewThr
+			e.reshareNodes = nil
+			e.resharePaths = nil
+			for _, idx := range entry.ReshareIndex {
+				n := e.findNode(idx)
+				if n == nil {
+					return nil, fmt.Errorf("reshare_group_created WAL entry references unknown node %d", idx)
+				}
+				e.reshareNodes = append(e.reshareNodes, n)
+				e.resharePaths = append(e.resharePaths, path.Join(e.basePath, fmt.Sprintf("public-%d.toml", idx)))
+			}
+		case WALReshareStarted:
+			// no in-memory state to restore beyond knowing a reshare is in flight;
+			// WALReshareGroupCreated already reconstructed e.reshareNodes/e.newThr
+		case WALReshareCompleted:
+			e.transition = entry.Transition
+		case WALNodeStopped, WALNodeStarted:
+			// node liveness is re-derived via Ping once the orchestrator resumes
+		case WALTransitionScheduled:
+			e.transition = entry.Transition
+		}
+	}
+	e.emit(PhaseSetup, nil, nil, 0, nil, "Resumed orchestrator from WAL at %s (%d entries replayed)", basePath, len(entries))
+	return e, nil
+}
+
+// reattachNodes reconstructs node.Node handles for an already-deployed set
+// of nodes, without rewriting their certificates or public key files.
+func reattachNodes(c cfg.Config) ([]node.Node, []string) {
+	nodes := newNodeHandles(c)
+	var paths []string
+	for _, n := range nodes {
+		paths = append(paths, path.Join(c.BasePath, fmt.Sprintf("public-%d.toml", n.Index())))
+	}
+	return nodes, paths
+}
@@ -0,0 +1,123 @@
+package lib
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/drand/drand/demo/cfg"
+)
+
+func TestWALJournalRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	w, err := newWALJournal(dir)
+	if err != nil {
+		t.Fatalf("newWALJournal: %v", err)
+	}
+
+	entries := []WALEntry{
+		{Type: WALNodesCreated, Config: newWALConfig(cfg.Config{N: 3, Thr: 2, Period: "1s"})},
+		{Type: WALDKGCompleted, GroupHash: "abc", Genesis: 42},
+		{Type: WALNodeStopped, NodeIndex: 1, CtrlAddr: "127.0.0.1:9001"},
+	}
+	for _, e := range entries {
+		if err := w.append(e); err != nil {
+			t.Fatalf("append(%+v): %v", e, err)
+		}
+	}
+	if err := w.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	got, err := readWAL(dir)
+	if err != nil {
+		t.Fatalf("readWAL: %v", err)
+	}
+	if len(got) != len(entries) {
+		t.Fatalf("readWAL returned %d entries, want %d", len(got), len(entries))
+	}
+	if got[0].Type != WALNodesCreated || got[0].Config == nil || got[0].Config.N != 3 {
+		t.Errorf("entry 0 = %+v, want a nodes_created entry with Config.N == 3", got[0])
+	}
+	if got[1].Type != WALDKGCompleted || got[1].GroupHash != "abc" || got[1].Genesis != 42 {
+		t.Errorf("entry 1 = %+v, want dkg_completed with GroupHash abc, Genesis 42", got[1])
+	}
+	if got[2].Type != WALNodeStopped || got[2].NodeIndex != 1 {
+		t.Errorf("entry 2 = %+v, want node_stopped for node 1", got[2])
+	}
+}
+
+func TestReadWALSkipsTruncatedTail(t *testing.T) {
+	dir := t.TempDir()
+	w, err := newWALJournal(dir)
+	if err != nil {
+		t.Fatalf("newWALJournal: %v", err)
+	}
+	if err := w.append(WALEntry{Type: WALNodesCreated, Config: newWALConfig(cfg.Config{N: 1})}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := w.append(WALEntry{Type: WALDKGCompleted, Genesis: 7}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := w.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	// simulate a write torn mid-sync by appending a truncated JSON line.
+	f, err := os.OpenFile(path.Join(dir, "orchestrator.wal"), os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		t.Fatalf("opening WAL to corrupt it: %v", err)
+	}
+	if _, err := f.WriteString(`{"type":"node_sto`); err != nil {
+		t.Fatalf("writing truncated line: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("closing corrupted WAL: %v", err)
+	}
+
+	got, err := readWAL(dir)
+	if err != nil {
+		t.Fatalf("readWAL should skip the truncated tail, not error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("readWAL returned %d entries, want the 2 valid ones before the truncated line", len(got))
+	}
+	if got[0].Type != WALNodesCreated || got[1].Type != WALDKGCompleted {
+		t.Errorf("readWAL entries = %+v, want [nodes_created, dkg_completed]", got)
+	}
+}
+
+// TestWALJournalConfigWithPgDSN guards against a regression where journaling
+// a cfg.Config with PgDSN set panicked: encoding/json.Marshal refuses any
+// struct with a func field, nil or not, so appending the raw *cfg.Config
+// always failed. walConfig must drop PgDSN before marshaling and hand it
+// back as nil on resume.
+func TestWALJournalConfigWithPgDSN(t *testing.T) {
+	dir := t.TempDir()
+	w, err := newWALJournal(dir)
+	if err != nil {
+		t.Fatalf("newWALJournal: %v", err)
+	}
+
+	c := cfg.Config{N: 3, Thr: 2, Period: "1s", PgDSN: func() string { return "postgres://example" }}
+	if err := w.append(WALEntry{Type: WALNodesCreated, Config: newWALConfig(c)}); err != nil {
+		t.Fatalf("append with PgDSN set: %v", err)
+	}
+	if err := w.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	got, err := readWAL(dir)
+	if err != nil {
+		t.Fatalf("readWAL: %v", err)
+	}
+	if len(got) != 1 || got[0].Config == nil {
+		t.Fatalf("readWAL = %+v, want one nodes_created entry with a Config", got)
+	}
+	if got[0].Config.N != 3 || got[0].Config.Thr != 2 {
+		t.Errorf("Config = %+v, want N=3, Thr=2", got[0].Config)
+	}
+	if restored := got[0].Config.toConfig(); restored.PgDSN != nil {
+		t.Errorf("toConfig().PgDSN = %v, want nil after a WAL round-trip", restored.PgDSN)
+	}
+}